@@ -3,252 +3,126 @@ package asciitimer
 import (
     "fmt"
     "time"
-    "os"
-    "os/signal"
-    "syscall"
-    "strings"
-    "unsafe"
-)
-
-type Termios struct {
-    Iflag  uint32
-    Oflag  uint32
-    Cflag  uint32
-    Lflag  uint32
-    Line   uint8
-    Cc     [19]uint8
-    Ispeed uint32
-    Ospeed uint32
-}
-
-var digits = [][]string{
-    { // 0
-        "█████",
-        "█   █",
-        "█   █",
-        "█   █",
-        "█████",
-    },
-    { // 1
-        "  █  ",
-        " ██  ",
-        "  █  ",
-        "  █  ",
-        "█████",
-    },
-    { // 2
-        "█████",
-        "    █",
-        "█████",
-        "█    ",
-        "█████",
-    },
-    { // 3
-        "█████",
-        "    █",
-        "█████",
-        "    █",
-        "█████",
-    },
-    { // 4
-        "█   █",
-        "█   █",
-        "█████",
-        "    █",
-        "    █",
-    },
-    { // 5
-        "█████",
-        "█    ",
-        "█████",
-        "    █",
-        "█████",
-    },
-    { // 6
-        "█████",
-        "█    ",
-        "█████",
-        "█   █",
-        "█████",
-    },
-    { // 7
-        "█████",
-        "    █",
-        "   █ ",
-        "  █  ",
-        " █   ",
-    },
-    { // 8
-        "█████",
-        "█   █",
-        "█████",
-        "█   █",
-        "█████",
-    },
-    { // 9
-        "█████",
-        "█   █",
-        "█████",
-        "    █",
-        "█████",
-    },
-}
-
-var colon = []string{
-    " ",
-    "█",
-    " ",
-    "█",
-    " ",
-}
-
-var pausedText = []string{
-    "█████  █████  █   █  █████  █████  ████ ",
-    "█   █  █   █  █   █  █      █      █   █",
-    "█████  █████  █   █  █████  █████  █   █",
-    "█      █   █  █   █      █  █      █   █",
-    "█      █   █  █████  █████  █████  ████ ",
-}
-
-const (
-    TCGETS = 0x5401
-    TCSETS = 0x5402
-    ECHO   = 0x00000008
-    ICANON = 0x00000002
-    VMIN   = 0x6
-    VTIME  = 0x5
 )
 
 func clearScreen() {
     fmt.Print("\033[H\033[2J")
 }
 
-func getASCIITime(h, m, s int) string {
-    timeStr := fmt.Sprintf("%02d:%02d:%02d", h, m, s)
-    
-    var result []string
-    for row := 0; row < 5; row++ {
-        line := ""
-        for _, char := range timeStr {
-            if char == ':' {
-                line += colon[row] + " "
-            } else {
-                digit := int(char - '0')
-                line += digits[digit][row] + " "
-            }
-        }
-        result = append(result, line)
-    }
-    
-    return strings.Join(result, "\n")
-}
+// maxDisplayedLaps is how many of the most recent laps are shown under
+// the clock; all of them are still kept in the history record.
+const maxDisplayedLaps = 5
 
-func makeRaw(fd int) (*Termios, error) {
-    termios := &Termios{}
-    
-    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
-        uintptr(fd),
-        uintptr(TCGETS),
-        uintptr(unsafe.Pointer(termios)))
-        
-    if errno != 0 {
-        return nil, errno
-    }
-    
-    oldTermios := *termios
-    
-    // Отключаем канонический режим и эхо
-    termios.Lflag &^= uint32(ICANON | ECHO)
-    termios.Cc[VMIN] = 1
-    termios.Cc[VTIME] = 0
-    
-    _, _, errno = syscall.Syscall(syscall.SYS_IOCTL,
-        uintptr(fd),
-        uintptr(TCSETS),
-        uintptr(unsafe.Pointer(termios)))
-        
-    if errno != 0 {
-        return nil, errno
-    }
-    
-    return &oldTermios, nil
+// RunTimer runs the stopwatch from zero.
+func RunTimer(opts ...Option) {
+    runStopwatch(time.Now(), 0, nil, opts...)
 }
 
-func restoreTerminal(fd int, oldState *Termios) error {
-    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
-        uintptr(fd),
-        uintptr(TCSETS),
-        uintptr(unsafe.Pointer(oldState)))
-    
-    if errno != 0 {
-        return errno
+// ResumeTimer continues the last session that never wrote a finished
+// history record (e.g. the terminal was closed mid-run), counting on from
+// its recorded start time less its recorded paused time. If there is no
+// such session, it behaves like RunTimer.
+func ResumeTimer(opts ...Option) {
+    rec, ok := lastUnfinishedSession()
+    if !ok {
+        runStopwatch(time.Now(), 0, nil, opts...)
+        return
     }
-    
-    return nil
+
+    // elapsed = time.Since(startTime) - pausedDuration, so keeping the
+    // recorded paused time means everything since the start, including
+    // the time the process was dead, counts as elapsed.
+    runStopwatch(rec.StartTime, rec.PausedDuration, rec.Laps, opts...)
 }
 
-func RunTimer() {
-    fd := int(os.Stdin.Fd())
-    oldState, err := makeRaw(fd)
+func runStopwatch(startTime time.Time, pausedDuration time.Duration, laps []Lap, opts ...Option) {
+    t, err := newTimer(opts...)
     if err != nil {
         fmt.Printf("Ошибка при настройке терминала: %v\n", err)
         return
     }
-    defer restoreTerminal(fd, oldState)
-
-    sigChan := make(chan os.Signal, 1)
-    signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+    defer t.Close()
 
     isPaused := false
-    startTime := time.Now()
-    pausedDuration := time.Duration(0)
     pauseStart := time.Time{}
+    laps = append([]Lap(nil), laps...)
 
-    go func() {
-        buf := make([]byte, 1)
-        for {
-            os.Stdin.Read(buf)
-            if buf[0] == 'p' || buf[0] == 'P' {
-                isPaused = !isPaused
-                if isPaused {
-                    pauseStart = time.Now()
-                } else if !pauseStart.IsZero() {
-                    pausedDuration += time.Since(pauseStart)
-                }
-            }
-            if buf[0] == 3 || buf[0] == 'q' {
-                restoreTerminal(fd, oldState)
-                os.Exit(0)
-            }
-        }
-    }()
+    recordSession(startTime, time.Since(startTime)-pausedDuration, pausedDuration, laps, false)
+    finish := func() {
+        recordSession(startTime, time.Since(startTime)-pausedDuration, pausedDuration, laps, true)
+    }
 
     clearScreen()
     fmt.Print("\033[?25l")
-    defer fmt.Print("\033[?25h")
 
     ticker := time.NewTicker(1 * time.Second)
-    
+    defer ticker.Stop()
+
     for {
         select {
-        case <-sigChan:
+        case <-t.sigChan:
+            finish()
             clearScreen()
             return
+        case key := <-t.keyEvents:
+            switch key {
+            case 'p', 'P':
+                isPaused = !isPaused
+                if isPaused {
+                    pauseStart = time.Now()
+                } else if !pauseStart.IsZero() {
+                    pausedDuration += time.Since(pauseStart)
+                }
+            case 'l', 'L':
+                laps = append(laps, Lap{Elapsed: time.Since(startTime) - pausedDuration, At: time.Now()})
+            case 3, 'q':
+                finish()
+                clearScreen()
+                return
+            }
         case <-ticker.C:
-            clearScreen()
             elapsed := time.Since(startTime) - pausedDuration
             hours := int(elapsed.Hours())
             minutes := int(elapsed.Minutes()) % 60
             seconds := int(elapsed.Seconds()) % 60
-            
-            fmt.Print("\033[1;1H")
-            fmt.Println(getASCIITime(hours, minutes, seconds))
-            
-            if isPaused {
-                fmt.Print("\033[1;1H") 
-                fmt.Println(strings.Join(pausedText, "\n"))
-            }
+
+            t.RenderClock("", hours, minutes, seconds, isPaused, lapFooter(laps)...)
         }
     }
 }
 
+// recordSession appends the current state of a stopwatch run to the
+// history file. Errors are swallowed: losing the history log is not worth
+// interrupting the timer over.
+func recordSession(startTime time.Time, elapsed, pausedDuration time.Duration, laps []Lap, finished bool) {
+    _ = appendHistory(SessionRecord{
+        StartTime:      startTime,
+        TotalElapsed:   elapsed,
+        PausedDuration: pausedDuration,
+        Laps:           laps,
+        Finished:       finished,
+    })
+}
+
+// lapFooter formats the most recent laps for display under the clock.
+func lapFooter(laps []Lap) []string {
+    if len(laps) == 0 {
+        return nil
+    }
+
+    start := 0
+    if len(laps) > maxDisplayedLaps {
+        start = len(laps) - maxDisplayedLaps
+    }
+
+    lines := make([]string, 0, len(laps)-start)
+    for i := start; i < len(laps); i++ {
+        elapsed := laps[i].Elapsed
+        hours := int(elapsed.Hours())
+        minutes := int(elapsed.Minutes()) % 60
+        seconds := int(elapsed.Seconds()) % 60
+        lines = append(lines, fmt.Sprintf("Lap %d: %02d:%02d:%02d", i+1, hours, minutes, seconds))
+    }
+    return lines
+}
+
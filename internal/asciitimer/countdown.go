@@ -0,0 +1,132 @@
+package asciitimer
+
+import (
+    "fmt"
+    "os"
+    "os/exec"
+    "time"
+)
+
+// phaseResult is how a single phase's run loop reports back to runSession.
+type phaseResult int
+
+const (
+    phaseCompleted phaseResult = iota
+    phaseSkipped
+    phaseQuit
+)
+
+// RunCountdown counts a single duration down to zero and fires the
+// completion alarm when it hits it.
+func RunCountdown(d time.Duration, opts ...Option) {
+    runSession(Session{Phases: []Phase{{Name: "Countdown", Duration: d}}}, opts...)
+}
+
+// RunPomodoro cycles the work/short-break/long-break phases described by
+// cfg, repeating the cycle until the user quits.
+func RunPomodoro(cfg PomodoroConfig, opts ...Option) {
+    session := NewPomodoroSession(cfg)
+    for {
+        if !runSession(session, opts...) {
+            return
+        }
+    }
+}
+
+// runSession drives a whole Session through the terminal, phase by phase.
+// It returns false once the user has quit (Ctrl-C/q), true if every phase
+// in the session ran to completion or was skipped.
+func runSession(session Session, opts ...Option) bool {
+    t, err := newTimer(opts...)
+    if err != nil {
+        fmt.Printf("Ошибка при настройке терминала: %v\n", err)
+        return false
+    }
+    defer t.Close()
+
+    fmt.Print("\033[?25l")
+
+    for _, phase := range session.Phases {
+        if runPhase(t, phase) == phaseQuit {
+            return false
+        }
+    }
+
+    return true
+}
+
+// runPhase counts a single phase down to zero, reacting to pause/skip/
+// reset/adjust key presses as they arrive.
+func runPhase(t *Timer, phase Phase) phaseResult {
+    remaining := phase.Duration
+    isPaused := false
+
+    ticker := time.NewTicker(1 * time.Second)
+    defer ticker.Stop()
+
+    render := func() {
+        hours := int(remaining.Hours())
+        minutes := int(remaining.Minutes()) % 60
+        seconds := int(remaining.Seconds()) % 60
+        t.RenderClock(phase.Name, hours, minutes, seconds, isPaused)
+    }
+    render()
+
+    for {
+        select {
+        case <-t.sigChan:
+            clearScreen()
+            return phaseQuit
+        case key := <-t.keyEvents:
+            switch key {
+            case 'p', 'P':
+                isPaused = !isPaused
+            case 's', 'S':
+                return phaseSkipped
+            case 'r', 'R':
+                remaining = phase.Duration
+                isPaused = false
+            case '+':
+                remaining += 30 * time.Second
+            case '-':
+                remaining -= 30 * time.Second
+                if remaining < 0 {
+                    remaining = 0
+                }
+            case 3, 'q':
+                clearScreen()
+                return phaseQuit
+            default:
+                continue
+            }
+            render()
+        case <-ticker.C:
+            if !isPaused {
+                remaining -= time.Second
+            }
+            if remaining <= 0 {
+                remaining = 0
+                render()
+                fireAlarm()
+                return phaseCompleted
+            }
+            render()
+        }
+    }
+}
+
+// fireAlarm signals that a phase has completed: it rings the terminal
+// bell, flashes the screen in inverted video, and runs an optional user
+// hook from ASCIITIMER_ALARM_CMD.
+func fireAlarm() {
+    for i := 0; i < 3; i++ {
+        fmt.Print("\033[7m\a")
+        time.Sleep(150 * time.Millisecond)
+        fmt.Print("\033[0m")
+        time.Sleep(150 * time.Millisecond)
+    }
+
+    if hook := os.Getenv("ASCIITIMER_ALARM_CMD"); hook != "" {
+        _ = exec.Command("sh", "-c", hook).Start()
+    }
+}
@@ -0,0 +1,128 @@
+package asciitimer
+
+import (
+    "bufio"
+    "encoding/json"
+    "os"
+    "path/filepath"
+    "time"
+)
+
+// Lap is a single captured split: how much time had elapsed (pauses
+// excluded) when the lap key was pressed.
+type Lap struct {
+    Elapsed time.Duration
+    At      time.Time
+}
+
+// SessionRecord is one stopwatch run as persisted to the history file.
+// RunTimer writes one with Finished=false as soon as it starts and a
+// second with Finished=true (same StartTime) when it exits cleanly, so
+// ResumeTimer can tell a completed run from one that never got the chance
+// to write its final record.
+type SessionRecord struct {
+    StartTime      time.Time
+    TotalElapsed   time.Duration
+    PausedDuration time.Duration
+    Laps           []Lap
+    Finished       bool
+}
+
+func historyPath() (string, error) {
+    home, err := os.UserHomeDir()
+    if err != nil {
+        return "", err
+    }
+    return filepath.Join(home, ".config", "ascii-timer", "history.jsonl"), nil
+}
+
+// appendHistory appends one JSON-encoded SessionRecord as a line in the
+// append-only history file, creating it (and its directory) if needed.
+func appendHistory(rec SessionRecord) error {
+    path, err := historyPath()
+    if err != nil {
+        return err
+    }
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+        return err
+    }
+
+    f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+    if err != nil {
+        return err
+    }
+    defer f.Close()
+
+    line, err := json.Marshal(rec)
+    if err != nil {
+        return err
+    }
+    _, err = f.Write(append(line, '\n'))
+    return err
+}
+
+// LoadHistory reads every session record ever written to the history
+// file, in the order they were appended. A missing history file is not an
+// error, it just means there is no history yet.
+func LoadHistory() ([]SessionRecord, error) {
+    path, err := historyPath()
+    if err != nil {
+        return nil, err
+    }
+
+    f, err := os.Open(path)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, nil
+        }
+        return nil, err
+    }
+    defer f.Close()
+
+    var sessions []SessionRecord
+    scanner := bufio.NewScanner(f)
+    for scanner.Scan() {
+        line := scanner.Bytes()
+        if len(line) == 0 {
+            continue
+        }
+        var rec SessionRecord
+        if err := json.Unmarshal(line, &rec); err != nil {
+            return sessions, err
+        }
+        sessions = append(sessions, rec)
+    }
+    return sessions, scanner.Err()
+}
+
+// Sessions is a convenience wrapper around LoadHistory for callers that
+// would rather see an empty list than handle a read error.
+func Sessions() []SessionRecord {
+    sessions, _ := LoadHistory()
+    return sessions
+}
+
+// lastUnfinishedSession returns the most recently started session whose
+// latest recorded state has Finished == false: one that never got the
+// chance to write its final record because the terminal closed or the
+// process was killed mid-run.
+func lastUnfinishedSession() (SessionRecord, bool) {
+    sessions := Sessions()
+
+    latest := make(map[int64]SessionRecord, len(sessions))
+    var order []int64
+    for _, rec := range sessions {
+        key := rec.StartTime.UnixNano()
+        if _, seen := latest[key]; !seen {
+            order = append(order, key)
+        }
+        latest[key] = rec
+    }
+
+    for i := len(order) - 1; i >= 0; i-- {
+        if rec := latest[order[i]]; !rec.Finished {
+            return rec, true
+        }
+    }
+    return SessionRecord{}, false
+}
@@ -0,0 +1,62 @@
+package asciitimer
+
+import (
+    "testing"
+    "time"
+)
+
+func TestLastUnfinishedSessionNoHistory(t *testing.T) {
+    t.Setenv("HOME", t.TempDir())
+
+    _, ok := lastUnfinishedSession()
+    if ok {
+        t.Fatal("lastUnfinishedSession() reported a session with no history file")
+    }
+}
+
+func TestLastUnfinishedSessionPicksLatestUnfinished(t *testing.T) {
+    t.Setenv("HOME", t.TempDir())
+
+    start1 := time.Now().Add(-2 * time.Hour).Truncate(time.Second)
+    start2 := time.Now().Add(-1 * time.Hour).Truncate(time.Second)
+
+    // start1: started and finished cleanly.
+    if err := appendHistory(SessionRecord{StartTime: start1, TotalElapsed: time.Minute, Finished: false}); err != nil {
+        t.Fatal(err)
+    }
+    if err := appendHistory(SessionRecord{StartTime: start1, TotalElapsed: 5 * time.Minute, Finished: true}); err != nil {
+        t.Fatal(err)
+    }
+
+    // start2: started, never finished (e.g. the terminal was closed).
+    if err := appendHistory(SessionRecord{StartTime: start2, TotalElapsed: 3 * time.Minute, Finished: false}); err != nil {
+        t.Fatal(err)
+    }
+
+    rec, ok := lastUnfinishedSession()
+    if !ok {
+        t.Fatal("lastUnfinishedSession() found nothing, want the start2 session")
+    }
+    if !rec.StartTime.Equal(start2) {
+        t.Errorf("StartTime = %v, want %v", rec.StartTime, start2)
+    }
+    if rec.TotalElapsed != 3*time.Minute {
+        t.Errorf("TotalElapsed = %v, want 3m", rec.TotalElapsed)
+    }
+}
+
+func TestLastUnfinishedSessionAllFinished(t *testing.T) {
+    t.Setenv("HOME", t.TempDir())
+
+    start := time.Now().Add(-time.Hour).Truncate(time.Second)
+    if err := appendHistory(SessionRecord{StartTime: start, TotalElapsed: time.Minute, Finished: false}); err != nil {
+        t.Fatal(err)
+    }
+    if err := appendHistory(SessionRecord{StartTime: start, TotalElapsed: 2 * time.Minute, Finished: true}); err != nil {
+        t.Fatal(err)
+    }
+
+    if _, ok := lastUnfinishedSession(); ok {
+        t.Fatal("lastUnfinishedSession() returned a session, want none: all sessions finished")
+    }
+}
@@ -0,0 +1,303 @@
+package asciitimer
+
+import (
+    "fmt"
+    "strings"
+)
+
+// Renderer turns an h:m:s clock reading into the lines that should be
+// printed to the terminal. Swapping renderers is how the timer adapts to
+// terminal width and color capability.
+type Renderer interface {
+    Render(h, m, s int) []string
+}
+
+var digits = [][]string{
+    { // 0
+        "█████",
+        "█   █",
+        "█   █",
+        "█   █",
+        "█████",
+    },
+    { // 1
+        "  █  ",
+        " ██  ",
+        "  █  ",
+        "  █  ",
+        "█████",
+    },
+    { // 2
+        "█████",
+        "    █",
+        "█████",
+        "█    ",
+        "█████",
+    },
+    { // 3
+        "█████",
+        "    █",
+        "█████",
+        "    █",
+        "█████",
+    },
+    { // 4
+        "█   █",
+        "█   █",
+        "█████",
+        "    █",
+        "    █",
+    },
+    { // 5
+        "█████",
+        "█    ",
+        "█████",
+        "    █",
+        "█████",
+    },
+    { // 6
+        "█████",
+        "█    ",
+        "█████",
+        "█   █",
+        "█████",
+    },
+    { // 7
+        "█████",
+        "    █",
+        "   █ ",
+        "  █  ",
+        " █   ",
+    },
+    { // 8
+        "█████",
+        "█   █",
+        "█████",
+        "█   █",
+        "█████",
+    },
+    { // 9
+        "█████",
+        "█   █",
+        "█████",
+        "    █",
+        "█████",
+    },
+}
+
+var colon = []string{
+    " ",
+    "█",
+    " ",
+    "█",
+    " ",
+}
+
+var pausedText = []string{
+    "█████  █████  █   █  █████  █████  ████ ",
+    "█   █  █   █  █   █  █      █      █   █",
+    "█████  █████  █   █  █████  █████  █   █",
+    "█      █   █  █   █      █  █      █   █",
+    "█      █   █  █████  █████  █████  ████ ",
+}
+
+// BlockRenderer draws digits as 5x5 grids of full block glyphs, the
+// original look of the timer.
+type BlockRenderer struct{}
+
+func (BlockRenderer) Render(h, m, s int) []string {
+    timeStr := fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+
+    result := make([]string, 5)
+    for row := 0; row < 5; row++ {
+        var line strings.Builder
+        for _, char := range timeStr {
+            if char == ':' {
+                line.WriteString(colon[row])
+                line.WriteString(" ")
+            } else {
+                digit := int(char - '0')
+                line.WriteString(digits[digit][row])
+                line.WriteString(" ")
+            }
+        }
+        result[row] = line.String()
+    }
+    return result
+}
+
+// BrailleRenderer packs the same digit shapes into braille dot patterns
+// (U+2800-28FF) for narrower terminals: an HH:MM:SS clock takes 28 columns
+// and 2 rows instead of BlockRenderer's 40 columns and 5 rows.
+type BrailleRenderer struct{}
+
+func (BrailleRenderer) Render(h, m, s int) []string {
+    timeStr := fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+
+    rows := len(brailleColon)
+    lines := make([]string, rows)
+    for i := range timeStr {
+        char := timeStr[i]
+        var glyph []string
+        if char == ':' {
+            glyph = brailleColon
+        } else {
+            glyph = brailleDigits[char-'0']
+        }
+        for row := 0; row < rows; row++ {
+            lines[row] += glyph[row] + " "
+        }
+    }
+    return lines
+}
+
+// SmallRenderer falls back to a single plain text line for terminals too
+// narrow for either block or braille digits.
+type SmallRenderer struct{}
+
+func (SmallRenderer) Render(h, m, s int) []string {
+    return []string{fmt.Sprintf("%02d:%02d:%02d", h, m, s)}
+}
+
+// brailleDigits/brailleColon are the BlockRenderer glyphs downsampled into
+// 2x4 braille dot cells.
+var (
+    brailleDigits = buildBrailleDigits()
+    brailleColon  = toBraille(colon)
+)
+
+// brailleClockWidth is how many columns a rendered HH:MM:SS clock takes up
+// under BrailleRenderer. pickRenderer uses it as the minimum width for
+// switching to BrailleRenderer, computed from the glyph table instead of
+// hardcoded so it can't drift out of sync if the glyphs change.
+var brailleClockWidth = maxLineWidth(BrailleRenderer{}.Render(0, 0, 0))
+
+func maxLineWidth(lines []string) int {
+    max := 0
+    for _, line := range lines {
+        if n := len([]rune(line)); n > max {
+            max = n
+        }
+    }
+    return max
+}
+
+func buildBrailleDigits() [][]string {
+    out := make([][]string, len(digits))
+    for i, glyph := range digits {
+        out[i] = toBraille(glyph)
+    }
+    return out
+}
+
+// toBraille downsamples a glyph made of full-width rows of '█'/' ' into
+// braille characters, each covering a 2 (wide) x 4 (tall) block of the
+// source glyph.
+func toBraille(glyph []string) []string {
+    rows := len(glyph)
+    cols := 0
+    for _, r := range glyph {
+        if n := len([]rune(r)); n > cols {
+            cols = n
+        }
+    }
+
+    lit := func(r, c int) bool {
+        if r < 0 || r >= rows {
+            return false
+        }
+        line := []rune(glyph[r])
+        if c < 0 || c >= len(line) {
+            return false
+        }
+        return line[c] != ' '
+    }
+
+    // Dot numbering follows the standard braille cell layout:
+    // (0,0)=0x01 (0,1)=0x08
+    // (1,0)=0x02 (1,1)=0x10
+    // (2,0)=0x04 (2,1)=0x20
+    // (3,0)=0x40 (3,1)=0x80
+    dotBits := [4][2]int{
+        {0x01, 0x08},
+        {0x02, 0x10},
+        {0x04, 0x20},
+        {0x40, 0x80},
+    }
+
+    brailleRows := (rows + 3) / 4
+    brailleCols := (cols + 1) / 2
+    out := make([]string, brailleRows)
+    for br := 0; br < brailleRows; br++ {
+        var line strings.Builder
+        for bc := 0; bc < brailleCols; bc++ {
+            mask := 0
+            for dr := 0; dr < 4; dr++ {
+                for dc := 0; dc < 2; dc++ {
+                    if lit(br*4+dr, bc*2+dc) {
+                        mask |= dotBits[dr][dc]
+                    }
+                }
+            }
+            line.WriteRune(rune(0x2800 + mask))
+        }
+        out[br] = line.String()
+    }
+    return out
+}
+
+// pausedLines returns the banner shown while the timer is paused. The
+// full-width block banner only reads well next to BlockRenderer output;
+// every other renderer gets a plain text marker instead.
+func pausedLines(r Renderer) []string {
+    if _, ok := r.(BlockRenderer); ok {
+        return pausedText
+    }
+    return []string{"-- PAUSED --"}
+}
+
+// ColorMode selects how (or whether) rendered clock lines are colorized.
+type ColorMode int
+
+const (
+    ColorNone ColorMode = iota
+    Color256
+    ColorTrueColor
+)
+
+// colorSeq returns the ANSI escape sequence that switches foreground color
+// according to mode, or "" if mode is ColorNone.
+func colorSeq(mode ColorMode, n, r, g, b int) string {
+    switch mode {
+    case Color256:
+        return fmt.Sprintf("\033[38;5;%dm", n)
+    case ColorTrueColor:
+        return fmt.Sprintf("\033[38;2;%d;%d;%dm", r, g, b)
+    default:
+        return ""
+    }
+}
+
+const colorReset = "\033[0m"
+
+// centerLines pads each line with leading spaces so the block is centered
+// within width columns. Lines already as wide as (or wider than) width are
+// left untouched.
+func centerLines(lines []string, width int) []string {
+    maxLen := 0
+    for _, line := range lines {
+        if n := len([]rune(line)); n > maxLen {
+            maxLen = n
+        }
+    }
+    if width <= maxLen {
+        return lines
+    }
+
+    pad := strings.Repeat(" ", (width-maxLen)/2)
+    out := make([]string, len(lines))
+    for i, line := range lines {
+        out[i] = pad + line
+    }
+    return out
+}
@@ -0,0 +1,74 @@
+package asciitimer
+
+import "testing"
+
+func TestToBraille(t *testing.T) {
+    // A 2x4 block of all-lit cells packs into a single full braille cell,
+    // U+28FF (all 8 dots set).
+    glyph := []string{
+        "██",
+        "██",
+        "██",
+        "██",
+    }
+    got := toBraille(glyph)
+    want := []string{string(rune(0x28FF))}
+    if len(got) != len(want) || got[0] != want[0] {
+        t.Fatalf("toBraille(all-lit) = %q, want %q", got, want)
+    }
+}
+
+func TestToBraillePartial(t *testing.T) {
+    // Only the top-left dot (bit 0x01) is lit.
+    glyph := []string{
+        "█ ",
+        "  ",
+        "  ",
+        "  ",
+    }
+    got := toBraille(glyph)
+    want := []string{string(rune(0x2800 + 0x01))}
+    if len(got) != len(want) || got[0] != want[0] {
+        t.Fatalf("toBraille(top-left) = %q, want %q", got, want)
+    }
+}
+
+func TestToBrailleDimensions(t *testing.T) {
+    // A 5x5 digit glyph should downsample to ceil(5/4)=2 rows of ceil(5/2)=3
+    // braille cells each.
+    got := toBraille(digits[0])
+    if len(got) != 2 {
+        t.Fatalf("got %d braille rows, want 2", len(got))
+    }
+    for i, row := range got {
+        if n := len([]rune(row)); n != 3 {
+            t.Errorf("row %d has %d braille cells, want 3", i, n)
+        }
+    }
+}
+
+func TestPickRenderer(t *testing.T) {
+    tests := []struct {
+        cols int
+        want Renderer
+    }{
+        {39, BrailleRenderer{}},
+        {40, BlockRenderer{}},
+        {100, BlockRenderer{}},
+        {brailleClockWidth - 1, SmallRenderer{}},
+        {brailleClockWidth, BrailleRenderer{}},
+    }
+    for _, tt := range tests {
+        got := pickRenderer(tt.cols)
+        if got != tt.want {
+            t.Errorf("pickRenderer(%d) = %T, want %T", tt.cols, got, tt.want)
+        }
+    }
+}
+
+func TestBrailleClockWidthMatchesRenderedOutput(t *testing.T) {
+    lines := BrailleRenderer{}.Render(0, 0, 0)
+    if got := maxLineWidth(lines); got != brailleClockWidth {
+        t.Fatalf("BrailleRenderer output is %d columns wide, but brailleClockWidth = %d", got, brailleClockWidth)
+    }
+}
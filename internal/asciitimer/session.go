@@ -0,0 +1,55 @@
+package asciitimer
+
+import "time"
+
+// Phase is a single named interval within a Session, e.g. "Work" or
+// "Short Break".
+type Phase struct {
+    Name     string
+    Duration time.Duration
+}
+
+// Session is an ordered list of phases to run back to back. It lets callers
+// script arbitrary interval workouts (HIIT rounds, tea steeping, pomodoros)
+// on top of the same countdown engine.
+type Session struct {
+    Phases []Phase
+}
+
+// PomodoroConfig describes the classic work/short-break/long-break ruleset.
+type PomodoroConfig struct {
+    Work         time.Duration
+    ShortBreak   time.Duration
+    LongBreak    time.Duration
+    WorkSessions int // number of work phases before a long break
+}
+
+// DefaultPomodoroConfig returns the traditional 25/5/15, 4-session ruleset.
+func DefaultPomodoroConfig() PomodoroConfig {
+    return PomodoroConfig{
+        Work:         25 * time.Minute,
+        ShortBreak:   5 * time.Minute,
+        LongBreak:    15 * time.Minute,
+        WorkSessions: 4,
+    }
+}
+
+// NewPomodoroSession builds one full pomodoro cycle: WorkSessions work
+// phases each followed by a short break, except the last which is followed
+// by a long break. RunPomodoro repeats this cycle until the user quits.
+func NewPomodoroSession(cfg PomodoroConfig) Session {
+    if cfg.WorkSessions <= 0 {
+        cfg.WorkSessions = 1
+    }
+
+    var phases []Phase
+    for i := 0; i < cfg.WorkSessions; i++ {
+        phases = append(phases, Phase{Name: "Work", Duration: cfg.Work})
+        if i == cfg.WorkSessions-1 {
+            phases = append(phases, Phase{Name: "Long Break", Duration: cfg.LongBreak})
+        } else {
+            phases = append(phases, Phase{Name: "Short Break", Duration: cfg.ShortBreak})
+        }
+    }
+    return Session{Phases: phases}
+}
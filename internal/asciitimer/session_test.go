@@ -0,0 +1,53 @@
+package asciitimer
+
+import (
+    "testing"
+    "time"
+)
+
+func TestNewPomodoroSession(t *testing.T) {
+    cfg := PomodoroConfig{
+        Work:         10 * time.Minute,
+        ShortBreak:   2 * time.Minute,
+        LongBreak:    5 * time.Minute,
+        WorkSessions: 3,
+    }
+    session := NewPomodoroSession(cfg)
+
+    want := []Phase{
+        {Name: "Work", Duration: cfg.Work},
+        {Name: "Short Break", Duration: cfg.ShortBreak},
+        {Name: "Work", Duration: cfg.Work},
+        {Name: "Short Break", Duration: cfg.ShortBreak},
+        {Name: "Work", Duration: cfg.Work},
+        {Name: "Long Break", Duration: cfg.LongBreak},
+    }
+
+    if len(session.Phases) != len(want) {
+        t.Fatalf("got %d phases, want %d: %+v", len(session.Phases), len(want), session.Phases)
+    }
+    for i, phase := range session.Phases {
+        if phase != want[i] {
+            t.Errorf("phase %d = %+v, want %+v", i, phase, want[i])
+        }
+    }
+}
+
+func TestNewPomodoroSessionZeroWorkSessions(t *testing.T) {
+    cfg := DefaultPomodoroConfig()
+    cfg.WorkSessions = 0
+    session := NewPomodoroSession(cfg)
+
+    want := []Phase{
+        {Name: "Work", Duration: cfg.Work},
+        {Name: "Long Break", Duration: cfg.LongBreak},
+    }
+    if len(session.Phases) != len(want) {
+        t.Fatalf("got %d phases, want %d: %+v", len(session.Phases), len(want), session.Phases)
+    }
+    for i, phase := range session.Phases {
+        if phase != want[i] {
+            t.Errorf("phase %d = %+v, want %+v", i, phase, want[i])
+        }
+    }
+}
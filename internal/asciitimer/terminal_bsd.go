@@ -0,0 +1,97 @@
+//go:build darwin || freebsd || openbsd || netbsd
+
+package asciitimer
+
+import (
+    "os"
+    "os/signal"
+    "syscall"
+
+    "golang.org/x/sys/unix"
+)
+
+// Termios aliases x/sys/unix's per-OS termios layout: Darwin, FreeBSD,
+// OpenBSD and NetBSD each have a different ABI (field widths, struct size,
+// even the TIOCGETA/TIOCSETA ioctl numbers differ), and unix.Termios/
+// unix.IoctlGetTermios/unix.IoctlSetTermios already encode the right one
+// for whichever of these GOOS this file is built for.
+type Termios = unix.Termios
+
+// makeRaw puts fd into raw mode the way cfmakeraw(3) would, and returns the
+// previous state so it can be restored later.
+func makeRaw(fd int) (*Termios, error) {
+    termios, err := unix.IoctlGetTermios(fd, unix.TIOCGETA)
+    if err != nil {
+        return nil, err
+    }
+
+    oldTermios := *termios
+
+    termios.Iflag &^= unix.BRKINT | unix.ICRNL | unix.INPCK | unix.ISTRIP | unix.IXON
+    termios.Oflag &^= unix.OPOST
+    termios.Cflag &^= unix.CSIZE | unix.PARENB
+    termios.Cflag |= unix.CS8
+    termios.Lflag &^= unix.ECHO | unix.ICANON | unix.IEXTEN | unix.ISIG
+    termios.Cc[unix.VMIN] = 0
+    termios.Cc[unix.VTIME] = 1
+
+    if err := unix.IoctlSetTermios(fd, unix.TIOCSETA, termios); err != nil {
+        return nil, err
+    }
+
+    return &oldTermios, nil
+}
+
+// readRaw reads directly from fd via the read(2) syscall, bypassing the Go
+// runtime's netpoller so raw mode's VMIN/VTIME termios timeout is honored
+// instead of ignored (see readKeys in timer.go).
+func readRaw(fd int, buf []byte) (int, error) {
+    return unix.Read(fd, buf)
+}
+
+func restoreTerminal(fd int, oldState *Termios) error {
+    return unix.IoctlSetTermios(fd, unix.TIOCSETA, oldState)
+}
+
+// isTTY reports whether fd refers to a terminal by probing it with the
+// same ioctl makeRaw uses.
+func isTTY(fd int) bool {
+    _, err := unix.IoctlGetTermios(fd, unix.TIOCGETA)
+    return err == nil
+}
+
+// getWinSize returns fd's terminal size in rows/columns.
+func getWinSize(fd int) (rows, cols int, err error) {
+    ws, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+    if err != nil {
+        return 0, 0, err
+    }
+    return int(ws.Row), int(ws.Col), nil
+}
+
+// enableANSI is a no-op on Unix: terminals interpret ANSI escape sequences
+// without opting in.
+func enableANSI(fd int) error { return nil }
+
+// notifyResize forwards SIGWINCH to ch (non-blocking; a pending resize
+// notification is enough, we don't need to queue every one) until done is
+// closed, at which point it stops the signal registration and the
+// forwarding goroutine exits.
+func notifyResize(ch chan<- struct{}, done <-chan struct{}) {
+    sigwinch := make(chan os.Signal, 1)
+    signal.Notify(sigwinch, syscall.SIGWINCH)
+    go func() {
+        defer signal.Stop(sigwinch)
+        for {
+            select {
+            case <-done:
+                return
+            case <-sigwinch:
+                select {
+                case ch <- struct{}{}:
+                default:
+                }
+            }
+        }
+    }()
+}
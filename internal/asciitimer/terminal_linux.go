@@ -0,0 +1,163 @@
+//go:build linux
+
+package asciitimer
+
+import (
+    "os"
+    "os/signal"
+    "syscall"
+    "unsafe"
+)
+
+type Termios struct {
+    Iflag  uint32
+    Oflag  uint32
+    Cflag  uint32
+    Lflag  uint32
+    Line   uint8
+    Cc     [19]uint8
+    Ispeed uint32
+    Ospeed uint32
+}
+
+const (
+    TCGETS = 0x5401
+    TCSETS = 0x5402
+
+    BRKINT = 0x0000002
+    ICRNL  = 0x0000100
+    INPCK  = 0x0000010
+    ISTRIP = 0x0000020
+    IXON   = 0x0000400
+
+    OPOST = 0x0000001
+
+    CSIZE  = 0x0000030
+    PARENB = 0x0000100
+    CS8    = 0x0000030
+
+    ECHO   = 0x0000008
+    ICANON = 0x0000002
+    IEXTEN = 0x0008000
+    ISIG   = 0x0000001
+
+    VMIN  = 0x6
+    VTIME = 0x5
+
+    TIOCGWINSZ = 0x5413
+)
+
+type winsize struct {
+    Row    uint16
+    Col    uint16
+    Xpixel uint16
+    Ypixel uint16
+}
+
+// makeRaw puts fd into raw mode the way cfmakeraw(3) would, and returns the
+// previous state so it can be restored later.
+func makeRaw(fd int) (*Termios, error) {
+    termios := &Termios{}
+
+    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
+        uintptr(fd),
+        uintptr(TCGETS),
+        uintptr(unsafe.Pointer(termios)))
+
+    if errno != 0 {
+        return nil, errno
+    }
+
+    oldTermios := *termios
+
+    termios.Iflag &^= uint32(BRKINT | ICRNL | INPCK | ISTRIP | IXON)
+    termios.Oflag &^= uint32(OPOST)
+    termios.Cflag &^= uint32(CSIZE | PARENB)
+    termios.Cflag |= uint32(CS8)
+    termios.Lflag &^= uint32(ECHO | ICANON | IEXTEN | ISIG)
+    termios.Cc[VMIN] = 0
+    termios.Cc[VTIME] = 1
+
+    _, _, errno = syscall.Syscall(syscall.SYS_IOCTL,
+        uintptr(fd),
+        uintptr(TCSETS),
+        uintptr(unsafe.Pointer(termios)))
+
+    if errno != 0 {
+        return nil, errno
+    }
+
+    return &oldTermios, nil
+}
+
+// readRaw reads directly from fd via the read(2) syscall, bypassing the Go
+// runtime's netpoller so raw mode's VMIN/VTIME termios timeout is honored
+// instead of ignored (see readKeys in timer.go).
+func readRaw(fd int, buf []byte) (int, error) {
+    return syscall.Read(fd, buf)
+}
+
+func restoreTerminal(fd int, oldState *Termios) error {
+    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
+        uintptr(fd),
+        uintptr(TCSETS),
+        uintptr(unsafe.Pointer(oldState)))
+
+    if errno != 0 {
+        return errno
+    }
+
+    return nil
+}
+
+// isTTY reports whether fd refers to a terminal by probing it with the
+// same ioctl makeRaw uses.
+func isTTY(fd int) bool {
+    termios := &Termios{}
+    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
+        uintptr(fd),
+        uintptr(TCGETS),
+        uintptr(unsafe.Pointer(termios)))
+    return errno == 0
+}
+
+// getWinSize returns fd's terminal size in rows/columns.
+func getWinSize(fd int) (rows, cols int, err error) {
+    ws := &winsize{}
+    _, _, errno := syscall.Syscall(syscall.SYS_IOCTL,
+        uintptr(fd),
+        uintptr(TIOCGWINSZ),
+        uintptr(unsafe.Pointer(ws)))
+
+    if errno != 0 {
+        return 0, 0, errno
+    }
+    return int(ws.Row), int(ws.Col), nil
+}
+
+// enableANSI is a no-op on Unix: terminals interpret ANSI escape sequences
+// without opting in.
+func enableANSI(fd int) error { return nil }
+
+// notifyResize forwards SIGWINCH to ch (non-blocking; a pending resize
+// notification is enough, we don't need to queue every one) until done is
+// closed, at which point it stops the signal registration and the
+// forwarding goroutine exits.
+func notifyResize(ch chan<- struct{}, done <-chan struct{}) {
+    sigwinch := make(chan os.Signal, 1)
+    signal.Notify(sigwinch, syscall.SIGWINCH)
+    go func() {
+        defer signal.Stop(sigwinch)
+        for {
+            select {
+            case <-done:
+                return
+            case <-sigwinch:
+                select {
+                case ch <- struct{}{}:
+                default:
+                }
+            }
+        }
+    }()
+}
@@ -0,0 +1,121 @@
+//go:build windows
+
+package asciitimer
+
+import (
+    "syscall"
+    "unsafe"
+)
+
+// Termios on Windows just wraps the console mode bits we need to restore;
+// there is no termios(3) equivalent.
+type Termios struct {
+    mode uint32
+}
+
+const (
+    enableEchoInput           = 0x0004
+    enableLineInput           = 0x0002
+    enableProcessedInput      = 0x0001
+    enableVirtualTerminalProc = 0x0004 // output-handle flag; see enableANSI
+)
+
+var (
+    kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+    procGetConsoleMode             = kernel32.NewProc("GetConsoleMode")
+    procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+    procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+)
+
+type coord struct {
+    X, Y int16
+}
+
+type smallRect struct {
+    Left, Top, Right, Bottom int16
+}
+
+type consoleScreenBufferInfo struct {
+    Size              coord
+    CursorPosition    coord
+    Attributes        uint16
+    Window            smallRect
+    MaximumWindowSize coord
+}
+
+// makeRaw disables line buffering and echo on the console, returning the
+// previous mode so it can be restored later.
+func makeRaw(fd int) (*Termios, error) {
+    var oldMode uint32
+    r, _, err := procGetConsoleMode.Call(uintptr(fd), uintptr(unsafe.Pointer(&oldMode)))
+    if r == 0 {
+        return nil, err
+    }
+
+    newMode := oldMode &^ uint32(enableEchoInput|enableLineInput|enableProcessedInput)
+
+    r, _, err = procSetConsoleMode.Call(uintptr(fd), uintptr(newMode))
+    if r == 0 {
+        return nil, err
+    }
+
+    return &Termios{mode: oldMode}, nil
+}
+
+// enableANSI turns on ENABLE_VIRTUAL_TERMINAL_PROCESSING on fd so the ANSI
+// escape sequences RenderClock writes are interpreted instead of printed
+// literally. This is an output-handle mode (unlike makeRaw's input-handle
+// one), so callers pass os.Stdout's fd, not stdin's. Older consoles that
+// don't support it just leave escape codes visible; that's not fatal.
+func enableANSI(fd int) error {
+    var mode uint32
+    r, _, err := procGetConsoleMode.Call(uintptr(fd), uintptr(unsafe.Pointer(&mode)))
+    if r == 0 {
+        return err
+    }
+
+    r, _, err = procSetConsoleMode.Call(uintptr(fd), uintptr(mode|enableVirtualTerminalProc))
+    if r == 0 {
+        return err
+    }
+    return nil
+}
+
+// readRaw reads directly from fd, bypassing the Go runtime's netpoller.
+// Windows consoles have no VMIN/VTIME equivalent, so this is a plain
+// blocking read; readKeys still only needs it to not go through os.Stdin.
+func readRaw(fd int, buf []byte) (int, error) {
+    return syscall.Read(syscall.Handle(fd), buf)
+}
+
+func restoreTerminal(fd int, oldState *Termios) error {
+    r, _, err := procSetConsoleMode.Call(uintptr(fd), uintptr(oldState.mode))
+    if r == 0 {
+        return err
+    }
+    return nil
+}
+
+// isTTY reports whether fd refers to a console by probing it with
+// GetConsoleMode.
+func isTTY(fd int) bool {
+    var mode uint32
+    r, _, _ := procGetConsoleMode.Call(uintptr(fd), uintptr(unsafe.Pointer(&mode)))
+    return r != 0
+}
+
+// getWinSize returns fd's console size in rows/columns.
+func getWinSize(fd int) (rows, cols int, err error) {
+    var info consoleScreenBufferInfo
+    r, _, callErr := procGetConsoleScreenBufferInfo.Call(uintptr(fd), uintptr(unsafe.Pointer(&info)))
+    if r == 0 {
+        return 0, 0, callErr
+    }
+    cols = int(info.Window.Right-info.Window.Left) + 1
+    rows = int(info.Window.Bottom-info.Window.Top) + 1
+    return rows, cols, nil
+}
+
+// notifyResize is a no-op on Windows: consoles don't deliver a SIGWINCH
+// equivalent, so the renderer only re-evaluates terminal size up front.
+func notifyResize(ch chan<- struct{}, done <-chan struct{}) {}
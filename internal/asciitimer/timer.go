@@ -0,0 +1,222 @@
+package asciitimer
+
+import (
+    "fmt"
+    "os"
+    "os/signal"
+    "strings"
+    "sync"
+    "syscall"
+)
+
+// Timer owns the raw terminal session shared by RunTimer, RunCountdown and
+// RunPomodoro: it puts stdin into raw mode, turns keystrokes into a
+// keyEvents channel, tracks terminal size/color, and guarantees the
+// terminal is restored exactly once on Close, however the run loop exits.
+type Timer struct {
+    fd        int
+    oldState  *Termios
+    sigChan   chan os.Signal
+    keyEvents chan rune
+    done      chan struct{}
+    closeOnce sync.Once
+
+    outFd      int
+    isTTY      bool
+    cols       int
+    resizeChan chan struct{}
+
+    renderer       Renderer
+    rendererLocked bool
+
+    colorMode              ColorMode
+    colorN                 int
+    colorR, colorG, colorB int
+}
+
+// Option configures optional Timer behavior such as forcing a renderer or
+// a color. Pass to RunTimer/RunCountdown/RunPomodoro.
+type Option func(*Timer)
+
+// WithRenderer forces a specific Renderer instead of picking one from the
+// terminal width, and stops automatic renderer switching on resize.
+func WithRenderer(r Renderer) Option {
+    return func(t *Timer) {
+        t.renderer = r
+        t.rendererLocked = true
+    }
+}
+
+// WithColor256 colors the rendered clock using an ANSI 256-color palette
+// index.
+func WithColor256(n int) Option {
+    return func(t *Timer) {
+        t.colorMode = Color256
+        t.colorN = n
+    }
+}
+
+// WithTrueColor colors the rendered clock using a 24-bit RGB color.
+func WithTrueColor(r, g, b int) Option {
+    return func(t *Timer) {
+        t.colorMode = ColorTrueColor
+        t.colorR, t.colorG, t.colorB = r, g, b
+    }
+}
+
+// newTimer puts stdin into raw mode and starts forwarding keystrokes and
+// SIGINT/SIGTERM to t.keyEvents/t.sigChan. Callers must call Close (usually
+// via defer) so the terminal is restored.
+func newTimer(opts ...Option) (*Timer, error) {
+    fd := int(os.Stdin.Fd())
+    oldState, err := makeRaw(fd)
+    if err != nil {
+        return nil, err
+    }
+
+    outFd := int(os.Stdout.Fd())
+    isTTY := isTTY(outFd)
+
+    cols := 80
+    if isTTY {
+        if _, c, err := getWinSize(outFd); err == nil {
+            cols = c
+        }
+        _ = enableANSI(outFd)
+    }
+
+    t := &Timer{
+        fd:         fd,
+        oldState:   oldState,
+        sigChan:    make(chan os.Signal, 1),
+        keyEvents:  make(chan rune, 16),
+        done:       make(chan struct{}),
+        outFd:      outFd,
+        isTTY:      isTTY,
+        cols:       cols,
+        resizeChan: make(chan struct{}, 1),
+        renderer:   pickRenderer(cols),
+    }
+
+    for _, opt := range opts {
+        opt(t)
+    }
+
+    if t.isTTY {
+        fmt.Print("\033[?1049h")
+        notifyResize(t.resizeChan, t.done)
+    }
+
+    signal.Notify(t.sigChan, syscall.SIGINT, syscall.SIGTERM)
+    go t.readKeys()
+
+    return t, nil
+}
+
+// pickRenderer chooses the widest renderer that still fits in cols
+// columns, falling back to SmallRenderer for very narrow terminals.
+func pickRenderer(cols int) Renderer {
+    switch {
+    case cols >= 40:
+        return BlockRenderer{}
+    case cols >= brailleClockWidth:
+        return BrailleRenderer{}
+    default:
+        return SmallRenderer{}
+    }
+}
+
+// readKeys reads one byte at a time from stdin and forwards it on
+// keyEvents. It reads via syscall.Read on the raw fd rather than
+// os.Stdin.Read: the latter goes through the runtime's netpoller, which
+// parks the goroutine until the fd is actually readable and ignores the
+// termios VMIN/VTIME timeout entirely, so it would block forever with no
+// keystroke instead of returning every ~100ms. Reading the raw fd directly
+// bypasses that integration, so raw mode's VMIN=0/VTIME=1 actually bounds
+// each read and lets this goroutine notice done and stop.
+func (t *Timer) readKeys() {
+    buf := make([]byte, 1)
+    for {
+        select {
+        case <-t.done:
+            return
+        default:
+        }
+
+        n, err := readRaw(t.fd, buf)
+        if err != nil || n == 0 {
+            continue
+        }
+
+        select {
+        case t.keyEvents <- rune(buf[0]):
+        case <-t.done:
+            return
+        }
+    }
+}
+
+// checkResize picks up a pending SIGWINCH notification (if any) and
+// re-queries the terminal size, switching renderers when the width crosses
+// a renderer's threshold unless the renderer was pinned with WithRenderer.
+func (t *Timer) checkResize() {
+    select {
+    case <-t.resizeChan:
+    default:
+        return
+    }
+
+    _, cols, err := getWinSize(t.outFd)
+    if err != nil {
+        return
+    }
+    t.cols = cols
+    if !t.rendererLocked {
+        t.renderer = pickRenderer(cols)
+    }
+}
+
+// RenderClock clears the screen and draws one frame: an optional label
+// line, the clock itself, a paused banner, and optional footer lines (e.g.
+// recorded laps), all centered on the current terminal width and
+// colorized when a TTY and color were requested.
+func (t *Timer) RenderClock(label string, h, m, s int, isPaused bool, footer ...string) {
+    t.checkResize()
+
+    var lines []string
+    if label != "" {
+        lines = append(lines, label)
+    }
+    lines = append(lines, t.renderer.Render(h, m, s)...)
+    if isPaused {
+        lines = append(lines, pausedLines(t.renderer)...)
+    }
+    lines = append(lines, footer...)
+    lines = centerLines(lines, t.cols)
+
+    clearScreen()
+    fmt.Print("\033[1;1H")
+
+    body := strings.Join(lines, "\n")
+    if t.isTTY {
+        if prefix := colorSeq(t.colorMode, t.colorN, t.colorR, t.colorG, t.colorB); prefix != "" {
+            body = prefix + body + colorReset
+        }
+    }
+    fmt.Println(body)
+}
+
+// Close stops the reader goroutine, restores the terminal, leaves the
+// alternate screen buffer, and shows the cursor again. It is safe to call
+// more than once.
+func (t *Timer) Close() {
+    t.closeOnce.Do(func() {
+        close(t.done)
+        signal.Stop(t.sigChan)
+        restoreTerminal(t.fd, t.oldState)
+        if t.isTTY {
+            fmt.Print("\033[?1049l")
+        }
+        fmt.Print("\033[?25h")
+    })
+}